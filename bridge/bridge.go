@@ -0,0 +1,44 @@
+// Package bridge let git-bug import and export bugs to and from external
+// issue trackers (GitHub, GitLab, ...).
+package bridge
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// ExternalIdKey is the OpBase metadata key used to make imports idempotent:
+// operations created from a remote issue or comment carry its id here, so
+// importing the same repo twice updates history in place instead of
+// duplicating it.
+const ExternalIdKey = "external_id"
+
+// Bridge import and export bugs against one external issue tracker. The
+// remote repository to talk to is read from the bridge's persisted Config
+// (see Configure), so callers only need to supply the local repo and a token.
+type Bridge interface {
+	// Name return the name used to select this bridge on the CLI (github, gitlab, ...)
+	Name() string
+	// Import fetch the remote issues and translate them into the local
+	// operations stream
+	Import(repo repository.Repo, token string) error
+	// Export push the local bugs as issues on the remote repo
+	Export(repo repository.Repo, token string) error
+}
+
+var bridges = map[string]func() Bridge{}
+
+// Register make a bridge implementation available under the given name
+func Register(name string, ctor func() Bridge) {
+	bridges[name] = ctor
+}
+
+// New instantiate a registered bridge by name
+func New(name string) (Bridge, error) {
+	ctor, ok := bridges[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bridge: %s", name)
+	}
+	return ctor(), nil
+}
@@ -0,0 +1,53 @@
+package bridge
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// Config hold what a configured bridge instance needs to talk to one
+// specific remote repo ("owner/name" for GitHub/GitLab).
+type Config struct {
+	Name string
+	Repo string
+}
+
+func bridgeDir(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), ".git", "git-bug", "bridges")
+}
+
+func configPath(repo repository.Repo, name string) string {
+	return path.Join(bridgeDir(repo), name+".json")
+}
+
+// Configure persist a bridge configuration so later `pull`/`push` only need
+// a token, not the remote repo again.
+func Configure(repo repository.Repo, conf Config) error {
+	err := os.MkdirAll(bridgeDir(repo), 0755)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(configPath(repo, conf.Name), data, 0600)
+}
+
+// LoadConfig read back a previously Configure'd bridge
+func LoadConfig(repo repository.Repo, name string) (Config, error) {
+	data, err := ioutil.ReadFile(configPath(repo, name))
+	if err != nil {
+		return Config{}, err
+	}
+
+	var conf Config
+	err = json.Unmarshal(data, &conf)
+	return conf, err
+}
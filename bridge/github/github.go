@@ -0,0 +1,141 @@
+// Package github implements a Bridge to import and export bugs against the
+// GitHub issues API.
+package github
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func init() {
+	bridge.Register("github", func() bridge.Bridge { return &Github{} })
+}
+
+// Github import and export bugs against the GitHub issues API. The target
+// repo ("owner/name") comes from the bridge config set by `git bug bridge configure`.
+type Github struct{}
+
+func (*Github) Name() string { return "github" }
+
+type issue struct {
+	Number int       `json:"number"`
+	Title  string    `json:"title"`
+	Body   string    `json:"body"`
+	State  string    `json:"state"`
+	User   ghUser    `json:"user"`
+	Labels []ghLabel `json:"labels"`
+}
+
+type ghUser struct {
+	Login string `json:"login"`
+}
+
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+type ghComment struct {
+	Id   int64  `json:"id"`
+	Body string `json:"body"`
+	User ghUser `json:"user"`
+}
+
+// Import fetch every issue (and its comments) of the configured repo and
+// translate them into the local operations stream. Operations tagged with an
+// already-seen bridge.ExternalIdKey are skipped, so importing twice doesn't
+// duplicate history.
+func (g *Github) Import(repo repository.Repo, token string) error {
+	conf, err := bridge.LoadConfig(repo, g.Name())
+	if err != nil {
+		return err
+	}
+
+	issues, err := fetchIssues(conf.Repo, token)
+	if err != nil {
+		return err
+	}
+
+	for _, iss := range issues {
+		comments, err := fetchComments(conf.Repo, iss.Number, token)
+		if err != nil {
+			return err
+		}
+
+		if err := bridge.ImportIssue(repo, toRemoteIssue(iss, comments)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Export is not implemented yet: pushing local bugs as GitHub issues needs
+// the ability to open/edit an issue through the API, left as a follow-up.
+func (g *Github) Export(repo repository.Repo, token string) error {
+	return fmt.Errorf("export to github is not implemented yet")
+}
+
+// toRemoteIssue translate a GitHub issue and its comments into the shape
+// bridge.ImportIssue needs, independently of the GitHub API's own shape.
+func toRemoteIssue(iss issue, comments []ghComment) bridge.RemoteIssue {
+	remoteComments := make([]bridge.RemoteComment, len(comments))
+	for i, c := range comments {
+		remoteComments[i] = bridge.RemoteComment{
+			ExternalId: fmt.Sprintf("github:comment:%d", c.Id),
+			Author:     bug.Person{Name: c.User.Login},
+			Message:    c.Body,
+		}
+	}
+
+	labels := make([]string, len(iss.Labels))
+	for i, l := range iss.Labels {
+		labels[i] = l.Name
+	}
+
+	return bridge.RemoteIssue{
+		ExternalId: fmt.Sprintf("github:%d", iss.Number),
+		Title:      iss.Title,
+		Message:    iss.Body,
+		Author:     bug.Person{Name: iss.User.Login},
+		Labels:     labels,
+		Closed:     iss.State == "closed",
+		Comments:   remoteComments,
+	}
+}
+
+func fetchIssues(repo string, token string) ([]issue, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/issues?state=all", repo)
+
+	var issues []issue
+	if err := getJSON(url, token, &issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func fetchComments(repo string, number int, token string) ([]ghComment, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/issues/%d/comments", repo, number)
+
+	var comments []ghComment
+	if err := getJSON(url, token, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func getJSON(url string, token string, out interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	return bridge.DoJSON(req, out)
+}
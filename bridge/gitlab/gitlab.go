@@ -0,0 +1,132 @@
+// Package gitlab implements a Bridge to import and export bugs against the
+// GitLab issues API.
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func init() {
+	bridge.Register("gitlab", func() bridge.Bridge { return &Gitlab{} })
+}
+
+// Gitlab import and export bugs against the GitLab issues API. The target
+// project ("owner/name") comes from the bridge config set by `git bug bridge configure`.
+type Gitlab struct{}
+
+func (*Gitlab) Name() string { return "gitlab" }
+
+type glIssue struct {
+	Iid         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	Author      glUser   `json:"author"`
+	Labels      []string `json:"labels"`
+}
+
+type glUser struct {
+	Username string `json:"username"`
+}
+
+type glNote struct {
+	Id     int64  `json:"id"`
+	Body   string `json:"body"`
+	Author glUser `json:"author"`
+}
+
+// Import fetch every issue (and its notes) of the configured project and
+// translate them into the local operations stream. Operations tagged with an
+// already-seen bridge.ExternalIdKey are skipped, so importing twice doesn't
+// duplicate history.
+func (g *Gitlab) Import(repo repository.Repo, token string) error {
+	conf, err := bridge.LoadConfig(repo, g.Name())
+	if err != nil {
+		return err
+	}
+
+	issues, err := fetchIssues(conf.Repo, token)
+	if err != nil {
+		return err
+	}
+
+	for _, iss := range issues {
+		notes, err := fetchNotes(conf.Repo, iss.Iid, token)
+		if err != nil {
+			return err
+		}
+
+		if err := bridge.ImportIssue(repo, toRemoteIssue(iss, notes)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Export is not implemented yet: pushing local bugs as GitLab issues needs
+// the ability to open/edit an issue through the API, left as a follow-up.
+func (g *Gitlab) Export(repo repository.Repo, token string) error {
+	return fmt.Errorf("export to gitlab is not implemented yet")
+}
+
+// toRemoteIssue translate a GitLab issue and its notes into the shape
+// bridge.ImportIssue needs, independently of the GitLab API's own shape.
+func toRemoteIssue(iss glIssue, notes []glNote) bridge.RemoteIssue {
+	remoteComments := make([]bridge.RemoteComment, len(notes))
+	for i, note := range notes {
+		remoteComments[i] = bridge.RemoteComment{
+			ExternalId: fmt.Sprintf("gitlab:note:%d", note.Id),
+			Author:     bug.Person{Name: note.Author.Username},
+			Message:    note.Body,
+		}
+	}
+
+	return bridge.RemoteIssue{
+		ExternalId: fmt.Sprintf("gitlab:%d", iss.Iid),
+		Title:      iss.Title,
+		Message:    iss.Description,
+		Author:     bug.Person{Name: iss.Author.Username},
+		Labels:     iss.Labels,
+		Closed:     iss.State == "closed",
+		Comments:   remoteComments,
+	}
+}
+
+func fetchIssues(project string, token string) ([]glIssue, error) {
+	u := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/issues?scope=all", url.QueryEscape(project))
+
+	var issues []glIssue
+	if err := getJSON(u, token, &issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func fetchNotes(project string, iid int, token string) ([]glNote, error) {
+	u := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/issues/%d/notes", url.QueryEscape(project), iid)
+
+	var notes []glNote
+	if err := getJSON(u, token, &notes); err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+func getJSON(u string, token string, out interface{}) error {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+
+	return bridge.DoJSON(req, out)
+}
@@ -0,0 +1,25 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// DoJSON perform req and decode its JSON response body into out. This is the
+// part every bridge's per-provider fetch helper needs identically; building
+// the request (URL, auth header) stays with each provider since that's the
+// part that actually differs between them.
+func DoJSON(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s for %s", req.URL.Host, resp.Status, req.URL)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,140 @@
+package bridge
+
+import (
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/operations"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// RemoteComment is a single comment/note on a RemoteIssue, already translated
+// from whatever shape the remote API uses into what ImportIssue needs.
+type RemoteComment struct {
+	ExternalId string
+	Author     bug.Person
+	Message    string
+}
+
+// RemoteIssue is a remote issue translated into the shape ImportIssue needs,
+// independently of which tracker (GitHub, GitLab, ...) it came from.
+type RemoteIssue struct {
+	ExternalId string
+	Title      string
+	Message    string
+	Author     bug.Person
+	Labels     []string
+	Closed     bool
+	Comments   []RemoteComment
+}
+
+// ImportIssue create or update the local bug matching issue.ExternalId. It
+// only appends what's actually missing: a new bug (with its create
+// operation) if none exists yet, any comment not already tagged with its
+// ExternalId, and a label/status change only when the remote actually
+// disagrees with the current snapshot. Calling it twice with the same
+// RemoteIssue is a no-op, which is what makes repeated imports idempotent.
+func ImportIssue(repo repository.Repo, issue RemoteIssue) error {
+	b, found, err := FindBugByExternalId(repo, issue.ExternalId)
+	if err != nil {
+		return err
+	}
+
+	b = applyRemoteIssue(b, found, issue)
+
+	return b.Commit(repo)
+}
+
+// applyRemoteIssue is the part of ImportIssue that doesn't need a
+// repository.Repo: given the bug already found for issue.ExternalId (nil and
+// found=false if none exists yet), append whatever operations are actually
+// missing and return the resulting bug. Kept separate from ImportIssue so it
+// can be tested without a repository.Repo.
+func applyRemoteIssue(b *bug.Bug, found bool, issue RemoteIssue) *bug.Bug {
+	if !found {
+		// Metadata is set before the operation is appended, like the comment
+		// loop below does. bug.Hash covers Metadata, so the external id is
+		// part of the create operation's hash and thus of the new bug's id.
+		createOp := operations.NewCreateOp(issue.Author, issue.Title, issue.Message, nil)
+		createOp.SetMetadata(ExternalIdKey, issue.ExternalId)
+
+		b = bug.NewBug()
+		b.Append(createOp)
+	}
+
+	for _, comment := range issue.Comments {
+		if hasExternalId(b, comment.ExternalId) {
+			continue
+		}
+
+		op := operations.NewAddCommentOp(comment.Author, comment.Message, nil)
+		op.SetMetadata(ExternalIdKey, comment.ExternalId)
+		b.Append(op)
+	}
+
+	// Only emit label/status operations when the remote actually disagrees
+	// with the current snapshot: re-importing an unchanged issue must not
+	// grow the op log.
+	snap := b.Compile()
+
+	wanted := make(map[bug.Label]bool, len(issue.Labels))
+	for _, l := range issue.Labels {
+		wanted[bug.Label(l)] = true
+	}
+	current := make(map[bug.Label]bool, len(snap.Labels))
+	for _, l := range snap.Labels {
+		current[l] = true
+	}
+
+	var added, removed []bug.Label
+	for l := range wanted {
+		if !current[l] {
+			added = append(added, l)
+		}
+	}
+	for l := range current {
+		if !wanted[l] {
+			removed = append(removed, l)
+		}
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		operations.ChangeLabels(b, issue.Author, added, removed)
+	}
+
+	wantedStatus := bug.OpenStatus
+	if issue.Closed {
+		wantedStatus = bug.ClosedStatus
+	}
+	if snap.Status != wantedStatus {
+		if wantedStatus == bug.ClosedStatus {
+			operations.Close(b, issue.Author)
+		} else {
+			operations.Open(b, issue.Author)
+		}
+	}
+
+	return b
+}
+
+// FindBugByExternalId scan the local bugs for one whose creating operation
+// carries the given external id.
+func FindBugByExternalId(repo repository.Repo, externalId string) (*bug.Bug, bool, error) {
+	for streamed := range bug.ReadAllLocalBugs(repo) {
+		if streamed.Err != nil {
+			return nil, false, streamed.Err
+		}
+
+		if hasExternalId(streamed.Bug, externalId) {
+			return streamed.Bug, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+func hasExternalId(b *bug.Bug, externalId string) bool {
+	for _, op := range b.Operations() {
+		if id, ok := op.GetMetadata(ExternalIdKey); ok && id == externalId {
+			return true
+		}
+	}
+	return false
+}
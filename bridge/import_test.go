@@ -0,0 +1,54 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// TestApplyRemoteIssueIdempotent checks that importing the same RemoteIssue
+// twice doesn't append duplicate comments or re-emit label/status changes
+// already reflected in the snapshot, which is what makes repeated imports of
+// an unchanged issue a no-op.
+func TestApplyRemoteIssueIdempotent(t *testing.T) {
+	author := bug.Person{Name: "rene"}
+
+	issue := RemoteIssue{
+		ExternalId: "1234",
+		Title:      "a bug",
+		Message:    "something's wrong",
+		Author:     author,
+		Labels:     []string{"bug", "help wanted"},
+		Closed:     true,
+		Comments: []RemoteComment{
+			{ExternalId: "c1", Author: author, Message: "first comment"},
+			{ExternalId: "c2", Author: author, Message: "second comment"},
+		},
+	}
+
+	b := applyRemoteIssue(nil, false, issue)
+
+	firstCount := len(b.Operations())
+	if firstCount == 0 {
+		t.Fatalf("expected operations to be appended on first import")
+	}
+
+	b = applyRemoteIssue(b, true, issue)
+
+	secondCount := len(b.Operations())
+	if secondCount != firstCount {
+		t.Fatalf("re-importing the same issue changed the operation count: %d vs %d",
+			firstCount, secondCount)
+	}
+
+	snap := b.Compile()
+	if snap.Status != bug.ClosedStatus {
+		t.Fatalf("expected the bug to be closed, got %s", snap.Status)
+	}
+	if len(snap.Labels) != len(issue.Labels) {
+		t.Fatalf("expected %d labels, got %d", len(issue.Labels), len(snap.Labels))
+	}
+	if len(snap.Comments) != len(issue.Comments)+1 {
+		t.Fatalf("expected %d comments, got %d", len(issue.Comments)+1, len(snap.Comments))
+	}
+}
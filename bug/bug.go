@@ -0,0 +1,132 @@
+package bug
+
+import "github.com/MichaelMure/git-bug/repository"
+
+// Interface is the interface used by operations to append themselves to a bug
+type Interface interface {
+	Append(op Operation)
+}
+
+// Bug hold the data of a bug: the ordered sequence of operations that
+// created and keep updating it.
+type Bug struct {
+	id         string
+	operations []Operation
+}
+
+var _ Interface = &Bug{}
+
+// NewBug create a new, empty bug
+func NewBug() *Bug {
+	return &Bug{}
+}
+
+// Append add a new operation to the bug
+func (b *Bug) Append(op Operation) {
+	b.operations = append(b.operations, op)
+}
+
+// Operations return every operation making up the bug, in application order.
+// Used by the bridge package to look up metadata stamped by a previous
+// import (see bridge.ExternalIdKey) and decide what's already been imported.
+func (b *Bug) Operations() []Operation {
+	return b.operations
+}
+
+// Id return the Bug identifier
+func (b *Bug) Id() string {
+	return b.id
+}
+
+// HumanId return the Bug identifier truncated for human consumption
+func (b *Bug) HumanId() string {
+	if len(b.id) < 7 {
+		return b.id
+	}
+	return b.id[:7]
+}
+
+// Compile replay every operation in order to produce the bug's current state
+func (b *Bug) Compile() Snapshot {
+	snap := Snapshot{}
+	for _, op := range b.operations {
+		snap = op.Apply(snap)
+	}
+	return snap
+}
+
+// Commit write the bug's operations to a gob file under .git/git-bug/bugs,
+// named after the bug's id, so it can be found again by Id on a later
+// ReadLocalBug/ReadAllLocalBugs.
+func (b *Bug) Commit(repo repository.Repo) error {
+	return commit(repo, b)
+}
+
+// StreamedBug is the result of reading a single bug off disk, used to stream
+// every local bug without loading them all in memory at once.
+type StreamedBug struct {
+	Bug *Bug
+	Err error
+}
+
+// ReadLocalBug read a single bug from the repository, identified by its id
+func ReadLocalBug(repo repository.Repo, id string) (*Bug, error) {
+	return readLocalBug(repo, id)
+}
+
+// ReadAllLocalBugs read every bug stored locally in the repository
+func ReadAllLocalBugs(repo repository.Repo) <-chan StreamedBug {
+	return readAllLocalBugs(repo)
+}
+
+// MergeStatus describe the outcome of merging one remote bug into the local repo
+type MergeStatus int
+
+const (
+	_ MergeStatus = iota
+	MergeStatusNew
+	MergeStatusUpdated
+	MergeStatusInvalid
+	MergeStatusNothing
+)
+
+func (s MergeStatus) String() string {
+	switch s {
+	case MergeStatusNew:
+		return "new"
+	case MergeStatusUpdated:
+		return "updated"
+	case MergeStatusInvalid:
+		return "invalid"
+	case MergeStatusNothing:
+		return "nothing"
+	default:
+		return "unknown merge status"
+	}
+}
+
+// MergeResult is the outcome of merging one remote bug
+type MergeResult struct {
+	Id     string
+	Status MergeStatus
+	Bug    *Bug
+	Err    error
+}
+
+// Fetch is not implemented: it always returns an error and leaves the local
+// bugs state unchanged
+func Fetch(repo repository.Repo, remote string) (string, error) {
+	return fetch(repo, remote)
+}
+
+// MergeAll return a channel that is already closed: merging bugs from a
+// remote is not implemented, so no MergeResult is ever sent
+func MergeAll(repo repository.Repo, remote string) <-chan MergeResult {
+	return mergeAll(repo, remote)
+}
+
+// Push is not implemented: it always returns an error and sends nothing to
+// the remote
+func Push(repo repository.Repo, remote string) (string, error) {
+	return push(repo, remote)
+}
@@ -0,0 +1,31 @@
+package bug
+
+import "github.com/MichaelMure/git-bug/util/git"
+
+// Comment represent a comment in a bug, along with its edit history
+type Comment struct {
+	// Hash is the hash of the operation that created this comment
+	// (CreateOperation for the root message, AddCommentOperation otherwise).
+	// EditCommentOperation targets a comment through this hash.
+	Hash     git.Hash
+	Message  string
+	Author   Person
+	Files    []git.Hash
+	UnixTime int64
+
+	// Edited is true once the comment has been amended at least once
+	Edited bool
+	// EditHash is the hash of the EditCommentOperation that last won the
+	// merge for this comment, used to break timestamp ties deterministically
+	// (see the supersedes helper in the operations package).
+	EditHash git.Hash
+	// History holds every previous version of the message, oldest first. The
+	// currently applied Message/UnixTime is not duplicated in it.
+	History []CommentEdit
+}
+
+// CommentEdit is a previous version of a comment's message
+type CommentEdit struct {
+	Message  string
+	UnixTime int64
+}
@@ -0,0 +1,24 @@
+package bug
+
+import "github.com/MichaelMure/git-bug/repository"
+
+// Person is the author or actor of an operation
+type Person struct {
+	Name  string
+	Email string
+}
+
+// GetUser return the Person matching the user identity configured in the repository
+func GetUser(repo repository.Repo) (Person, error) {
+	name, err := repo.GetUserName()
+	if err != nil {
+		return Person{}, err
+	}
+
+	email, err := repo.GetUserEmail()
+	if err != nil {
+		return Person{}, err
+	}
+
+	return Person{Name: name, Email: email}, nil
+}
@@ -0,0 +1,4 @@
+package bug
+
+// Label is a tag attached to a bug
+type Label string
@@ -0,0 +1,101 @@
+package bug
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// OperationType identify the type of an Operation, used for (de)serialization
+type OperationType int
+
+const (
+	_ OperationType = iota
+	CreateOp
+	AddCommentOp
+	EditCommentOp
+	EditTitleOp
+	SetStatusOp
+	LabelChangeOp
+)
+
+// Operation mutate a Snapshot to reflect its effect. Every concrete operation
+// embeds OpBase and implements Apply with a value receiver, so it also picks
+// up OpBase's Metadata accessors for free.
+type Operation interface {
+	// Apply the operation to a snapshot, returning the resulting snapshot
+	Apply(snapshot Snapshot) Snapshot
+	// GetMetadata retrieve a key/value pair previously set with SetMetadata
+	GetMetadata(key string) (string, bool)
+	// SetMetadata attach an arbitrary key/value pair to the operation. Used
+	// by the bridge package to stamp an external id on imported operations,
+	// so repeated imports can recognize what they already created.
+	SetMetadata(key string, value string)
+}
+
+// OpBase is the set of fields common to every operation
+type OpBase struct {
+	OperationType OperationType
+	Author        Person
+	UnixTime      int64
+	// Metadata holds arbitrary key/value pairs set with SetMetadata. It's a
+	// map, not used as a value itself, precisely so SetMetadata can keep a
+	// value receiver: concrete operation types are passed around by value
+	// (var _ Operation = XxxOperation{}) but a map is a reference, so every
+	// copy of an OpBase still shares and mutates the same underlying data.
+	Metadata map[string]string
+}
+
+// NewOpBase is the constructor for an OpBase
+func NewOpBase(opType OperationType, author Person) OpBase {
+	return OpBase{
+		OperationType: opType,
+		Author:        author,
+		UnixTime:      time.Now().Unix(),
+		Metadata:      make(map[string]string),
+	}
+}
+
+// Time return the time the operation was created
+func (op OpBase) Time() time.Time {
+	return time.Unix(op.UnixTime, 0)
+}
+
+// Hash compute a deterministic hash for a full operation, used to address it
+// (for example to target a comment for a later edit) regardless of the order
+// in which it gets merged from different peers. It takes the whole concrete
+// operation (not just the embedded OpBase), so that for example two edits by
+// the same author in the same second, but with different content, still get
+// different hashes -- the operation types that get addressed by hash
+// (CreateOperation, AddCommentOperation, EditCommentOperation and
+// EditTitleOperation) call this passing themselves:
+//
+//	func (op EditCommentOperation) Hash() git.Hash { return bug.Hash(op) }
+func Hash(op interface{}) git.Hash {
+	data, err := json.Marshal(op)
+	if err != nil {
+		// op is built internally from already-serializable fields
+		panic(err)
+	}
+
+	sum := sha256.Sum256(data)
+	return git.Hash(hex.EncodeToString(sum[:]))
+}
+
+// GetMetadata retrieve a key/value pair previously set with SetMetadata
+func (op OpBase) GetMetadata(key string) (string, bool) {
+	val, ok := op.Metadata[key]
+	return val, ok
+}
+
+// SetMetadata attach an arbitrary key/value pair to the operation. Call this
+// before appending the operation to a Bug, the same way the comment import
+// path does -- the metadata itself is safe to set on a copy at any point
+// since Metadata is a map, but the intent ("this operation carries this
+// external id") should be settled before it becomes part of the history.
+func (op OpBase) SetMetadata(key string, value string) {
+	op.Metadata[key] = value
+}
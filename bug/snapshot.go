@@ -0,0 +1,35 @@
+package bug
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// Snapshot is a compiled state of a Bug at a given point, obtained by
+// replaying its operations in order
+type Snapshot struct {
+	Title     string
+	Comments  []Comment
+	Author    Person
+	CreatedAt time.Time
+
+	Status Status
+	Labels []Label
+
+	// TitleEdited is true once the title has been changed at least once
+	TitleEdited bool
+	// TitleEditTime/TitleEditHash identify the EditTitleOperation currently
+	// applied, used the same way as Comment.EditHash to break ties when
+	// merges replay edits out of order.
+	TitleEditTime int64
+	TitleEditHash git.Hash
+	// TitleHistory holds every previous title, oldest first.
+	TitleHistory []TitleEdit
+}
+
+// TitleEdit is a previous version of a bug's title
+type TitleEdit struct {
+	Title    string
+	UnixTime int64
+}
@@ -0,0 +1,35 @@
+package bug
+
+import "fmt"
+
+// Status is the open/closed state of a bug
+type Status int
+
+const (
+	_ Status = iota
+	OpenStatus
+	ClosedStatus
+)
+
+func (s Status) String() string {
+	switch s {
+	case OpenStatus:
+		return "open"
+	case ClosedStatus:
+		return "closed"
+	default:
+		return "unknown status"
+	}
+}
+
+// StatusFromString parse a status as used in a query field ("status:open")
+func StatusFromString(str string) (Status, error) {
+	switch str {
+	case "open":
+		return OpenStatus, nil
+	case "closed":
+		return ClosedStatus, nil
+	default:
+		return 0, fmt.Errorf("unknown status: %s", str)
+	}
+}
@@ -0,0 +1,107 @@
+package bug
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func bugsDirPath(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), ".git", "git-bug", "bugs")
+}
+
+func bugFilePath(repo repository.Repo, id string) string {
+	return path.Join(bugsDirPath(repo), id+".gob")
+}
+
+// commit persist a bug's operations to disk. The first time a bug is
+// committed, its id is derived from the hash of the operation that created
+// it, so it stays stable across processes without needing a counter.
+func commit(repo repository.Repo, b *Bug) error {
+	if len(b.operations) == 0 {
+		return fmt.Errorf("can't commit a bug with no operations")
+	}
+
+	if b.id == "" {
+		// b.operations[0] is an Operation (interface), not the concrete type
+		// that defines Hash() -- Hash is generic over any value, so it
+		// reflects the underlying concrete operation fine either way.
+		b.id = string(Hash(b.operations[0]))
+	}
+
+	if err := os.MkdirAll(bugsDirPath(repo), 0755); err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(b.operations); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(bugFilePath(repo, b.id), data.Bytes(), 0644)
+}
+
+func readLocalBug(repo repository.Repo, id string) (*Bug, error) {
+	data, err := ioutil.ReadFile(bugFilePath(repo, id))
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ops); err != nil {
+		return nil, err
+	}
+
+	return &Bug{id: id, operations: ops}, nil
+}
+
+// readAllLocalBugs stream every bug stored locally, one file read at a time,
+// so callers don't need to hold the whole repo's history in memory at once.
+func readAllLocalBugs(repo repository.Repo) <-chan StreamedBug {
+	out := make(chan StreamedBug)
+
+	go func() {
+		defer close(out)
+
+		entries, err := ioutil.ReadDir(bugsDirPath(repo))
+		if os.IsNotExist(err) {
+			return
+		}
+		if err != nil {
+			out <- StreamedBug{Err: err}
+			return
+		}
+
+		for _, entry := range entries {
+			id := strings.TrimSuffix(entry.Name(), ".gob")
+			b, err := readLocalBug(repo, id)
+			out <- StreamedBug{Bug: b, Err: err}
+		}
+	}()
+
+	return out
+}
+
+// fetch/mergeAll/push would sync bugs through a real git remote (dedicated
+// refs namespace, like upstream git-bug does). That transport isn't part of
+// this snapshot, so remote sync is left unimplemented for now, same as the
+// bridges' Export side.
+func fetch(repo repository.Repo, remote string) (string, error) {
+	return "", fmt.Errorf("fetch from remote %s is not implemented yet", remote)
+}
+
+func mergeAll(repo repository.Repo, remote string) <-chan MergeResult {
+	out := make(chan MergeResult)
+	close(out)
+	return out
+}
+
+func push(repo repository.Repo, remote string) (string, error) {
+	return "", fmt.Errorf("push to remote %s is not implemented yet", remote)
+}
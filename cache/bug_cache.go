@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/operations"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// BugCache wrap a Bug, providing an edition API that keeps the repo cache
+// excerpts in sync with every change.
+type BugCache struct {
+	repoCache *RepoCache
+	bug       *bug.Bug
+}
+
+func NewBugCache(repoCache *RepoCache, b *bug.Bug) *BugCache {
+	return &BugCache{
+		repoCache: repoCache,
+		bug:       b,
+	}
+}
+
+// Snapshot return the current snapshot of state of the bug
+func (c *BugCache) Snapshot() *bug.Snapshot {
+	snap := c.bug.Compile()
+	return &snap
+}
+
+// notifyUpdated inform the cache that the bug changed and needs to be persisted
+func (c *BugCache) notifyUpdated() error {
+	return c.repoCache.bugUpdated(c.bug.Id())
+}
+
+// AddComment add a new comment to the bug
+func (c *BugCache) AddComment(message string) error {
+	return c.AddCommentWithFiles(message, nil)
+}
+
+// AddCommentWithFiles add a new comment to the bug, with attached files
+func (c *BugCache) AddCommentWithFiles(message string, files []git.Hash) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.WithLock(func() error {
+		operations.CommentWithFiles(c.bug, author, message, files)
+		return c.bug.Commit(c.repoCache.repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.notifyUpdated()
+}
+
+// EditComment edit a comment of the bug, identified by the hash of the
+// operation that created it
+func (c *BugCache) EditComment(target git.Hash, message string) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.WithLock(func() error {
+		operations.EditComment(c.bug, author, target, message)
+		return c.bug.Commit(c.repoCache.repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.notifyUpdated()
+}
+
+// EditTitle change the title of the bug
+func (c *BugCache) EditTitle(title string) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.WithLock(func() error {
+		operations.EditTitle(c.bug, author, title)
+		return c.bug.Commit(c.repoCache.repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.notifyUpdated()
+}
+
+// Open reopen the bug
+func (c *BugCache) Open() error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.WithLock(func() error {
+		operations.Open(c.bug, author)
+		return c.bug.Commit(c.repoCache.repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.notifyUpdated()
+}
+
+// Close close the bug
+func (c *BugCache) Close() error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.WithLock(func() error {
+		operations.Close(c.bug, author)
+		return c.bug.Commit(c.repoCache.repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.notifyUpdated()
+}
+
+// ChangeLabels add and/or remove labels from the bug, atomically
+func (c *BugCache) ChangeLabels(added, removed []bug.Label) error {
+	author, err := bug.GetUser(c.repoCache.repo)
+	if err != nil {
+		return err
+	}
+
+	err = c.repoCache.WithLock(func() error {
+		operations.ChangeLabels(c.bug, author, added, removed)
+		return c.bug.Commit(c.repoCache.repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.notifyUpdated()
+}
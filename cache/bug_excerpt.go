@@ -0,0 +1,75 @@
+package cache
+
+import "github.com/MichaelMure/git-bug/bug"
+
+// excerptsSchemaVersion is bumped every time BugExcerpt's shape changes in a
+// way that isn't compatible with what might already be on disk. loadExcerpts
+// checks it and the cache gets rebuilt transparently on mismatch.
+const excerptsSchemaVersion = 4
+
+// BugExcerpt hold a subset of a bug's data, enough to filter and sort a bug
+// list without rehydrating and compiling the full operations history.
+type BugExcerpt struct {
+	SchemaVersion int
+
+	Id             string
+	Title          string
+	CreateUnixTime int64
+	EditUnixTime   int64
+
+	Status bug.Status
+	Labels []bug.Label
+	Author bug.Person
+
+	// Messages holds every comment's message, in order. Kept around so the
+	// index can be built from message bodies too, not just title/author/label.
+	Messages []string
+}
+
+func NewBugExcerpt(b *bug.Bug, snap *bug.Snapshot) *BugExcerpt {
+	e := &BugExcerpt{
+		SchemaVersion:  excerptsSchemaVersion,
+		Id:             b.Id(),
+		Title:          snap.Title,
+		CreateUnixTime: snap.CreatedAt.Unix(),
+		EditUnixTime:   snap.CreatedAt.Unix(),
+		Status:         snap.Status,
+		Labels:         snap.Labels,
+		Author:         snap.Author,
+		Messages:       make([]string, len(snap.Comments)),
+	}
+
+	for i, comment := range snap.Comments {
+		e.Messages[i] = comment.Message
+	}
+
+	if n := len(snap.Comments); n > 0 {
+		e.EditUnixTime = snap.Comments[n-1].UnixTime
+	}
+
+	return e
+}
+
+type BugsById []*BugExcerpt
+
+func (b BugsById) Len() int      { return len(b) }
+func (b BugsById) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b BugsById) Less(i, j int) bool {
+	return b[i].Id < b[j].Id
+}
+
+type BugsByCreationTime []*BugExcerpt
+
+func (b BugsByCreationTime) Len() int      { return len(b) }
+func (b BugsByCreationTime) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b BugsByCreationTime) Less(i, j int) bool {
+	return b[i].CreateUnixTime < b[j].CreateUnixTime
+}
+
+type BugsByEditTime []*BugExcerpt
+
+func (b BugsByEditTime) Len() int      { return len(b) }
+func (b BugsByEditTime) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b BugsByEditTime) Less(i, j int) bool {
+	return b[i].EditUnixTime < b[j].EditUnixTime
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+	"path"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// compactThreshold is the number of pending deltas after which the excerpts
+// log gets folded back into a single snapshot file, keeping it from growing
+// forever.
+const compactThreshold = 256
+
+// excerptDelta is one entry of the append-only excerpts log: an updated
+// excerpt for Id, or (Excerpt == nil) a tombstone recording that Id was
+// removed.
+type excerptDelta struct {
+	Id      string
+	Excerpt *BugExcerpt
+}
+
+func repoExcerptsLogFilePath(repo repository.Repo) string {
+	return path.Join(repo.GetPath(), ".git", "git-bug", excerptsLogFile)
+}
+
+// appendExcerptDelta append a single delta to the log. This is O(1)
+// regardless of how many bugs the repo holds, unlike rewriting the whole
+// excerpts snapshot on every update.
+func appendExcerptDelta(repo repository.Repo, delta excerptDelta) error {
+	f, err := os.OpenFile(repoExcerptsLogFilePath(repo), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gob.NewEncoder(f).Encode(delta)
+}
+
+// readExcerptLog replay every delta appended since the last compaction.
+func readExcerptLog(repo repository.Repo) ([]excerptDelta, error) {
+	f, err := os.Open(repoExcerptsLogFilePath(repo))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoder := gob.NewDecoder(f)
+
+	var deltas []excerptDelta
+	for {
+		var delta excerptDelta
+		err := decoder.Decode(&delta)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		deltas = append(deltas, delta)
+	}
+
+	return deltas, nil
+}
+
+// clearExcerptLog remove the log file, used right after a compaction folded
+// every pending delta into the snapshot.
+func clearExcerptLog(repo repository.Repo) error {
+	err := os.Remove(repoExcerptsLogFilePath(repo))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
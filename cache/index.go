@@ -0,0 +1,98 @@
+package cache
+
+import "strings"
+
+// index is an in-memory inverted index (token -> set of bug ids) built from
+// excerpt titles, messages, authors and labels. It lets QueryBugs narrow down
+// to the matching bugs directly instead of scanning every excerpt, which is
+// what makes field queries like "title:foo author:alice" sublinear.
+type index struct {
+	tokens map[string]map[string]bool
+}
+
+func newIndex() *index {
+	return &index{tokens: make(map[string]map[string]bool)}
+}
+
+func newIndexFromExcerpts(excerpts map[string]*BugExcerpt) *index {
+	idx := newIndex()
+	for _, excerpt := range excerpts {
+		idx.indexExcerpt(excerpt)
+	}
+	return idx
+}
+
+// indexExcerpt add every token of the given excerpt to the index
+func (idx *index) indexExcerpt(e *BugExcerpt) {
+	for _, tok := range excerptTokens(e) {
+		set, ok := idx.tokens[tok]
+		if !ok {
+			set = make(map[string]bool)
+			idx.tokens[tok] = set
+		}
+		set[e.Id] = true
+	}
+}
+
+// removeExcerpt drop e's own tokens from the index. Called with the excerpt's
+// previous version before re-indexing a bug that changed, so stale tokens
+// don't linger. Only recomputes and walks e's own tokens, so the cost of an
+// edit is proportional to that one bug's vocabulary, not the whole index's.
+func (idx *index) removeExcerpt(e *BugExcerpt) {
+	for _, tok := range excerptTokens(e) {
+		set, ok := idx.tokens[tok]
+		if !ok {
+			continue
+		}
+		delete(set, e.Id)
+		if len(set) == 0 {
+			delete(idx.tokens, tok)
+		}
+	}
+}
+
+// search return the set of bug ids matching every given token. It returns nil
+// if no token is given, or if a single token is given and it is not in the
+// index; QueryBugs then falls back to a full scan.
+func (idx *index) search(tokens []string) map[string]bool {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	result := idx.tokens[tokens[0]]
+	for _, tok := range tokens[1:] {
+		matches := idx.tokens[tok]
+		intersected := make(map[string]bool, len(result))
+		for id := range result {
+			if matches[id] {
+				intersected[id] = true
+			}
+		}
+		result = intersected
+	}
+
+	return result
+}
+
+// excerptTokens compute the set of searchable tokens for an excerpt.
+func excerptTokens(e *BugExcerpt) []string {
+	var tokens []string
+
+	for _, word := range strings.Fields(e.Title) {
+		tokens = append(tokens, "title:"+strings.ToLower(word))
+	}
+
+	for _, message := range e.Messages {
+		for _, word := range strings.Fields(message) {
+			tokens = append(tokens, "message:"+strings.ToLower(word))
+		}
+	}
+
+	tokens = append(tokens, "author:"+strings.ToLower(e.Author.Name))
+
+	for _, l := range e.Labels {
+		tokens = append(tokens, "label:"+strings.ToLower(string(l)))
+	}
+
+	return tokens
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"syscall"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// repoLock is a handle on the advisory lock taken for the lifetime of a
+// RepoCache. Unlock releases it.
+type repoLock interface {
+	Unlock() error
+}
+
+// lockRepo acquire an advisory lock on the repository, held for as long as
+// the RepoCache is open. It prefers a real OS file lock (flock on Unix,
+// LockFileEx on Windows, see lock_unix.go/lock_windows.go) so a crashed
+// process can never leave a stale lock behind. It falls back to the legacy
+// pid-file scheme only when the filesystem doesn't support file locking
+// (ENOTSUP, seen on some network filesystems).
+func lockRepo(repo repository.Repo) (repoLock, error) {
+	lockPath := repoLockFilePath(repo)
+
+	l, err := lockFile(lockPath)
+	if err == syscall.ENOTSUP {
+		return lockPid(repo, lockPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/process"
+)
+
+// keepStaleLockConfigKey disables the automatic cleanup of a stale pid-file
+// lock. Some network filesystems can make a locking process look dead to a
+// host that doesn't actually own the lock; operators on such a setup should
+// set this key so git-bug never removes a lock file it can't be sure about.
+const keepStaleLockConfigKey = "git-bug.keep-stale-lock"
+
+// pidLock is the legacy lock scheme, used only as a fallback when the
+// filesystem doesn't support a real file lock (see lock.go).
+type pidLock struct {
+	path string
+}
+
+func (l *pidLock) Unlock() error {
+	return os.Remove(l.path)
+}
+
+func lockPid(repo repository.Repo, lockPath string) (repoLock, error) {
+	err := pidFileIsAvailable(repo, lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pid := fmt.Sprintf("%d", os.Getpid())
+	_, err = f.WriteString(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pidLock{path: lockPath}, nil
+}
+
+// pidFileIsAvailable check is the given repository is locked by a Cache.
+// Note: this is a smart function that will cleanup the lock file if the
+// corresponding process is not there anymore, unless the repo config sets
+// keepStaleLockConfigKey.
+// If no error is returned, the repo is free to edit.
+func pidFileIsAvailable(repo repository.Repo, lockPath string) error {
+	f, err := os.Open(lockPath)
+
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err == nil {
+		// lock file already exist
+		buf, err := ioutil.ReadAll(io.LimitReader(f, 10))
+		if err != nil {
+			return err
+		}
+		if len(buf) == 10 {
+			return fmt.Errorf("the lock file should be < 10 bytes")
+		}
+
+		pid, err := strconv.Atoi(string(buf))
+		if err != nil {
+			return err
+		}
+
+		if process.IsRunning(pid) {
+			return fmt.Errorf("the repository you want to access is already locked by the process pid %d", pid)
+		}
+
+		keepStale, _ := repo.ReadConfigBool(keepStaleLockConfigKey)
+		if keepStale {
+			return fmt.Errorf("a lock file is present but the corresponding process is not running; "+
+				"refusing to remove it because %s is set", keepStaleLockConfigKey)
+		}
+
+		// The lock file is just laying there after a crash, clean it
+		fmt.Println("A lock file is present but the corresponding process is not, removing it.")
+		err = f.Close()
+		if err != nil {
+			return err
+		}
+
+		return os.Remove(lockPath)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// +build windows
+
+package cache
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+type fileLock struct {
+	f *os.File
+}
+
+// lockFile takes an exclusive, non-blocking lock on path via LockFileEx,
+// held open for the lifetime of the returned lock.
+func lockFile(path string) (repoLock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	ol := new(windows.Overlapped)
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK | windows.LOCKFILE_FAIL_IMMEDIATELY)
+	err = windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, ol)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("the repository is already locked by another git-bug process")
+	}
+
+	return &fileLock{f: f}, nil
+}
+
+func (l *fileLock) Unlock() error {
+	defer l.f.Close()
+	ol := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(l.f.Fd()), 0, 1, 0, ol)
+}
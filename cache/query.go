@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+type OrderBy int
+
+const (
+	OrderById OrderBy = iota
+	OrderByCreation
+	OrderByEdit
+)
+
+type OrderDirection int
+
+const (
+	OrderAscending OrderDirection = iota
+	OrderDescending
+)
+
+// Query hold the criteria used to filter and sort a bug list, without ever
+// needing to compile a full Snapshot.
+type Query struct {
+	OrderBy        OrderBy
+	OrderDirection OrderDirection
+
+	StatusFilter  *bug.Status
+	LabelFilter   string
+	AuthorFilter  string
+	TitleFilter   string
+	MessageFilter string
+}
+
+// Match report whether the given excerpt satisfies every criteria of the query
+func (q *Query) Match(excerpt *BugExcerpt) bool {
+	if q.StatusFilter != nil && excerpt.Status != *q.StatusFilter {
+		return false
+	}
+
+	if q.LabelFilter != "" {
+		found := false
+		for _, l := range excerpt.Labels {
+			if string(l) == q.LabelFilter {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.AuthorFilter != "" && !strings.EqualFold(excerpt.Author.Name, q.AuthorFilter) {
+		return false
+	}
+
+	if q.TitleFilter != "" && !strings.Contains(strings.ToLower(excerpt.Title), strings.ToLower(q.TitleFilter)) {
+		return false
+	}
+
+	if q.MessageFilter != "" {
+		found := false
+		filter := strings.ToLower(q.MessageFilter)
+		for _, message := range excerpt.Messages {
+			if strings.Contains(strings.ToLower(message), filter) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// indexTokens return the tokens this query can look up directly in the
+// inverted index (title/message/author/label), so RepoCache.QueryBugs can
+// narrow down the candidate set before falling back to Match for the rest.
+func (q *Query) indexTokens() []string {
+	var tokens []string
+
+	for _, word := range strings.Fields(q.TitleFilter) {
+		tokens = append(tokens, "title:"+strings.ToLower(word))
+	}
+	for _, word := range strings.Fields(q.MessageFilter) {
+		tokens = append(tokens, "message:"+strings.ToLower(word))
+	}
+	if q.AuthorFilter != "" {
+		tokens = append(tokens, "author:"+strings.ToLower(q.AuthorFilter))
+	}
+	if q.LabelFilter != "" {
+		tokens = append(tokens, "label:"+strings.ToLower(q.LabelFilter))
+	}
+
+	return tokens
+}
+
+// NewQuery parse a query string like "status:open label:bug author:rene"
+// into a Query ready to be passed to RepoCache.QueryBugs.
+func NewQuery(query string) (*Query, error) {
+	q := &Query{}
+
+	for _, field := range strings.Fields(query) {
+		parts := strings.SplitN(field, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid query field: %s", field)
+		}
+
+		key, value := parts[0], parts[1]
+
+		switch key {
+		case "status":
+			status, err := bug.StatusFromString(value)
+			if err != nil {
+				return nil, err
+			}
+			q.StatusFilter = &status
+		case "label":
+			q.LabelFilter = value
+		case "author":
+			q.AuthorFilter = value
+		case "title":
+			q.TitleFilter = value
+		case "message":
+			q.MessageFilter = value
+		default:
+			return nil, fmt.Errorf("unknown query field: %s", key)
+		}
+	}
+
+	return q, nil
+}
@@ -4,26 +4,34 @@ import (
 	"bytes"
 	"encoding/gob"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"os"
 	"path"
 	"sort"
-	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/operations"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/git"
-	"github.com/MichaelMure/git-bug/util/process"
 )
 
 type RepoCache struct {
 	// the underlying repo
 	repo repository.Repo
+	// the lock held for the lifetime of the cache, guarding against another
+	// git-bug process touching the same repo concurrently
+	lock repoLock
+	// guards every mutating operation below, so two goroutines in this same
+	// process (say, two concurrent MergeAll callbacks) can't race either
+	mu sync.Mutex
 	// excerpt of bugs data for all bugs
 	excerpts map[string]*BugExcerpt
+	// inverted index (token -> bug ids) built from the excerpts, used to
+	// evaluate field queries without scanning every excerpt
+	idx *index
+	// number of excerpt deltas appended to the log since the last compaction
+	pendingDeltas int
 	// bug loaded in memory
 	bugs map[string]*BugCache
 }
@@ -34,10 +42,11 @@ func NewRepoCache(r repository.Repo) (*RepoCache, error) {
 		bugs: make(map[string]*BugCache),
 	}
 
-	err := c.lock()
+	l, err := lockRepo(r)
 	if err != nil {
-		return &RepoCache{}, err
+		return nil, err
 	}
+	c.lock = l
 
 	err = c.loadExcerpts()
 	if err == nil {
@@ -46,7 +55,7 @@ func NewRepoCache(r repository.Repo) (*RepoCache, error) {
 
 	c.buildAllExcerpt()
 
-	return c, c.writeExcerpts()
+	return c, c.compactExcerpts()
 }
 
 // Repository return the underlying repository.
@@ -55,47 +64,60 @@ func (c *RepoCache) Repository() repository.Repo {
 	return c.repo
 }
 
-func (c *RepoCache) lock() error {
-	lockPath := repoLockFilePath(c.repo)
+// WithLock run f while holding the cache's lock, guaranteeing that no other
+// goroutine in this process mutates the cache concurrently. Every method
+// that writes to the repo or the excerpts cache goes through it.
+func (c *RepoCache) WithLock(f func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return f()
+}
 
-	err := repoIsAvailable(c.repo)
-	if err != nil {
-		return err
-	}
+func (c *RepoCache) Close() error {
+	c.bugs = make(map[string]*BugCache)
+	c.excerpts = make(map[string]*BugExcerpt)
+	return c.lock.Unlock()
+}
 
-	f, err := os.Create(lockPath)
-	if err != nil {
-		return err
+// updateExcerpt record a new excerpt for id: update the in-memory map and
+// index, then append a single delta to the on-disk log. The log is O(1) per
+// call; the full snapshot only gets rewritten every compactThreshold deltas.
+func (c *RepoCache) updateExcerpt(id string, excerpt *BugExcerpt) error {
+	if old, ok := c.excerpts[id]; ok {
+		c.idx.removeExcerpt(old)
 	}
 
-	pid := fmt.Sprintf("%d", os.Getpid())
-	_, err = f.WriteString(pid)
+	c.excerpts[id] = excerpt
+	c.idx.indexExcerpt(excerpt)
+
+	err := appendExcerptDelta(c.repo, excerptDelta{Id: id, Excerpt: excerpt})
 	if err != nil {
 		return err
 	}
 
-	return f.Close()
-}
+	c.pendingDeltas++
+	if c.pendingDeltas >= compactThreshold {
+		return c.compactExcerpts()
+	}
 
-func (c *RepoCache) Close() error {
-	lockPath := repoLockFilePath(c.repo)
-	return os.Remove(lockPath)
+	return nil
 }
 
 // bugUpdated is a callback to trigger when the excerpt of a bug changed,
 // that is each time a bug is updated
 func (c *RepoCache) bugUpdated(id string) error {
-	b, ok := c.bugs[id]
-	if !ok {
-		panic("missing bug in the cache")
-	}
-
-	c.excerpts[id] = NewBugExcerpt(b.bug, b.Snapshot())
+	return c.WithLock(func() error {
+		b, ok := c.bugs[id]
+		if !ok {
+			panic("missing bug in the cache")
+		}
 
-	return c.writeExcerpts()
+		return c.updateExcerpt(id, NewBugExcerpt(b.bug, b.Snapshot()))
+	})
 }
 
-// loadExcerpts will try to read from the disk the bug excerpt file
+// loadExcerpts will try to read from disk the excerpts snapshot, then replay
+// the delta log accumulated on top of it since the last compaction.
 func (c *RepoCache) loadExcerpts() error {
 	excerptsPath := repoExcerptsFilePath(c.repo)
 
@@ -113,12 +135,53 @@ func (c *RepoCache) loadExcerpts() error {
 		return err
 	}
 
+	if err := validateExcerptsSchema(excerpts, "cache"); err != nil {
+		return err
+	}
+
+	deltas, err := readExcerptLog(c.repo)
+	if err != nil {
+		return err
+	}
+
+	for _, delta := range deltas {
+		if delta.Excerpt == nil {
+			// tombstone: the bug was removed
+			delete(excerpts, delta.Id)
+			continue
+		}
+		if delta.Excerpt.SchemaVersion != excerptsSchemaVersion {
+			return fmt.Errorf("excerpts log is using schema version %d, expected %d",
+				delta.Excerpt.SchemaVersion, excerptsSchemaVersion)
+		}
+		excerpts[delta.Id] = delta.Excerpt
+	}
+
 	c.excerpts = excerpts
+	c.idx = newIndexFromExcerpts(excerpts)
+	c.pendingDeltas = len(deltas)
+
 	return nil
 }
 
-// writeExcerpts will serialize on disk the BugExcerpt array
-func (c *RepoCache) writeExcerpts() error {
+// validateExcerptsSchema report an error if any excerpt wasn't written with
+// the schema this binary expects. NewRepoCache treats that error as "stale
+// on-disk format" and transparently falls back to buildAllExcerpt instead of
+// trusting data it can't interpret.
+func validateExcerptsSchema(excerpts map[string]*BugExcerpt, source string) error {
+	for _, excerpt := range excerpts {
+		if excerpt.SchemaVersion != excerptsSchemaVersion {
+			return fmt.Errorf("excerpts %s is using schema version %d, expected %d",
+				source, excerpt.SchemaVersion, excerptsSchemaVersion)
+		}
+	}
+	return nil
+}
+
+// compactExcerpts rewrite the full excerpts snapshot from the in-memory map
+// and clears the delta log, which readExcerptLog would otherwise replay on
+// top of it.
+func (c *RepoCache) compactExcerpts() error {
 	var data bytes.Buffer
 
 	encoder := gob.NewEncoder(&data)
@@ -140,7 +203,18 @@ func (c *RepoCache) writeExcerpts() error {
 		return err
 	}
 
-	return f.Close()
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = clearExcerptLog(c.repo)
+	if err != nil {
+		return err
+	}
+
+	c.pendingDeltas = 0
+	return nil
 }
 
 func repoExcerptsFilePath(repo repository.Repo) string {
@@ -159,6 +233,9 @@ func (c *RepoCache) buildAllExcerpt() {
 		c.excerpts[b.Bug.Id()] = NewBugExcerpt(b.Bug, &snap)
 	}
 
+	c.idx = newIndexFromExcerpts(c.excerpts)
+	c.pendingDeltas = 0
+
 	fmt.Println("Done.")
 }
 
@@ -193,6 +270,10 @@ func (c *RepoCache) ResolveBugPrefix(prefix string) (*BugCache, error) {
 		return nil, fmt.Errorf("Multiple matching bug found:\n%s", strings.Join(matching, "\n"))
 	}
 
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("no matching bug found for prefix %s", prefix)
+	}
+
 	return c.ResolveBug(matching[0])
 }
 
@@ -201,9 +282,22 @@ func (c *RepoCache) QueryBugs(query *Query) []string {
 		return c.AllBugsIds()
 	}
 
+	// candidates defaults to every known bug; when the query has indexable
+	// tokens (title/message/author/label), narrow it down through the inverted
+	// index first instead of scanning every excerpt.
+	candidates := c.excerpts
+	if tokens := query.indexTokens(); len(tokens) > 0 {
+		if matches := c.idx.search(tokens); matches != nil {
+			candidates = make(map[string]*BugExcerpt, len(matches))
+			for id := range matches {
+				candidates[id] = c.excerpts[id]
+			}
+		}
+	}
+
 	var filtered []*BugExcerpt
 
-	for _, excerpt := range c.excerpts {
+	for _, excerpt := range candidates {
 		if query.Match(excerpt) {
 			filtered = append(filtered, excerpt)
 		}
@@ -264,25 +358,34 @@ func (c *RepoCache) NewBug(title string, message string) (*BugCache, error) {
 // NewBugWithFiles create a new bug with attached files for the message
 // The new bug is written in the repository (commit)
 func (c *RepoCache) NewBugWithFiles(title string, message string, files []git.Hash) (*BugCache, error) {
-	author, err := bug.GetUser(c.repo)
-	if err != nil {
-		return nil, err
-	}
+	var cached *BugCache
 
-	b, err := operations.CreateWithFiles(author, title, message, files)
-	if err != nil {
-		return nil, err
-	}
+	err := c.WithLock(func() error {
+		author, err := bug.GetUser(c.repo)
+		if err != nil {
+			return err
+		}
+
+		b, err := operations.CreateWithFiles(author, title, message, files)
+		if err != nil {
+			return err
+		}
 
-	err = b.Commit(c.repo)
+		err = b.Commit(c.repo)
+		if err != nil {
+			return err
+		}
+
+		cached = NewBugCache(c, b)
+		c.bugs[b.Id()] = cached
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	cached := NewBugCache(c, b)
-	c.bugs[b.Id()] = cached
-
-	err = c.bugUpdated(b.Id())
+	err = c.bugUpdated(cached.bug.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -313,10 +416,16 @@ func (c *RepoCache) MergeAll(remote string) <-chan bug.MergeResult {
 			id := result.Id
 
 			switch result.Status {
-			case bug.MsgMergeNew, bug.MsgMergeUpdated:
+			case bug.MergeStatusNew, bug.MergeStatusUpdated:
 				b := result.Bug
 				snap := b.Compile()
-				c.excerpts[id] = NewBugExcerpt(b, &snap)
+
+				err := c.WithLock(func() error {
+					return c.updateExcerpt(id, NewBugExcerpt(b, &snap))
+				})
+				if err != nil {
+					panic(err)
+				}
 
 			default:
 			}
@@ -324,7 +433,7 @@ func (c *RepoCache) MergeAll(remote string) <-chan bug.MergeResult {
 			out <- result
 		}
 
-		err := c.writeExcerpts()
+		err := c.WithLock(c.compactExcerpts)
 
 		// No easy way out here ..
 		if err != nil {
@@ -343,63 +452,3 @@ func (c *RepoCache) Push(remote string) (string, error) {
 func repoLockFilePath(repo repository.Repo) string {
 	return path.Join(repo.GetPath(), ".git", "git-bug", lockfile)
 }
-
-// repoIsAvailable check is the given repository is locked by a Cache.
-// Note: this is a smart function that will cleanup the lock file if the
-// corresponding process is not there anymore.
-// If no error is returned, the repo is free to edit.
-func repoIsAvailable(repo repository.Repo) error {
-	lockPath := repoLockFilePath(repo)
-
-	// Todo: this leave way for a racey access to the repo between the test
-	// if the file exist and the actual write. It's probably not a problem in
-	// practice because using a repository will be done from user interaction
-	// or in a context where a single instance of git-bug is already guaranteed
-	// (say, a server with the web UI running). But still, that might be nice to
-	// have a mutex or something to guard that.
-
-	// Todo: this will fail if somehow the filesystem is shared with another
-	// computer. Should add a configuration that prevent the cleaning of the
-	// lock file
-
-	f, err := os.Open(lockPath)
-
-	if err != nil && !os.IsNotExist(err) {
-		return err
-	}
-
-	if err == nil {
-		// lock file already exist
-		buf, err := ioutil.ReadAll(io.LimitReader(f, 10))
-		if err != nil {
-			return err
-		}
-		if len(buf) == 10 {
-			return fmt.Errorf("the lock file should be < 10 bytes")
-		}
-
-		pid, err := strconv.Atoi(string(buf))
-		if err != nil {
-			return err
-		}
-
-		if process.IsRunning(pid) {
-			return fmt.Errorf("the repository you want to access is already locked by the process pid %d", pid)
-		}
-
-		// The lock file is just laying there after a crash, clean it
-
-		fmt.Println("A lock file is present but the corresponding process is not, removing it.")
-		err = f.Close()
-		if err != nil {
-			return err
-		}
-
-		os.Remove(lockPath)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// buildBenchCache build a RepoCache with n excerpts and their inverted index
+// already populated, without touching disk or a repository.Repo.
+func buildBenchCache(n int) *RepoCache {
+	c := &RepoCache{
+		excerpts: make(map[string]*BugExcerpt, n),
+	}
+
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("%040x", i)
+		c.excerpts[id] = &BugExcerpt{
+			SchemaVersion: excerptsSchemaVersion,
+			Id:            id,
+			Title:         fmt.Sprintf("bug number %d about rendering", i),
+			Author:        bug.Person{Name: "rene"},
+			Labels:        []bug.Label{"bug"},
+		}
+	}
+
+	c.idx = newIndexFromExcerpts(c.excerpts)
+
+	return c
+}
+
+// BenchmarkQueryBugsIndexed10k shows that a title/author/label query stays
+// fast as the repo grows, since it goes through the inverted index instead
+// of scanning every excerpt.
+func BenchmarkQueryBugsIndexed10k(b *testing.B) {
+	c := buildBenchCache(10000)
+
+	query, err := NewQuery("title:rendering author:rene")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.QueryBugs(query)
+	}
+}
+
+// BenchmarkReindexExcerpt10k shows that re-indexing a single bug's excerpt --
+// the removeExcerpt + indexExcerpt pair every comment/label/title edit goes
+// through via updateExcerpt -- stays cheap as the repo grows, since
+// removeExcerpt only touches that bug's own tokens instead of scanning the
+// whole index's vocabulary.
+func BenchmarkReindexExcerpt10k(b *testing.B) {
+	c := buildBenchCache(10000)
+
+	id := fmt.Sprintf("%040x", 0)
+	excerpt := c.excerpts[id]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.idx.removeExcerpt(excerpt)
+		c.idx.indexExcerpt(excerpt)
+	}
+}
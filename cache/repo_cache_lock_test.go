@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestWithLockSerializesConcurrentCallers runs many goroutines through
+// WithLock incrementing a plain (non-atomic) counter. If WithLock ever let
+// two callers run concurrently, this would race and the final count would
+// come out wrong (or the race detector would flag it under `go test -race`).
+func TestWithLockSerializesConcurrentCallers(t *testing.T) {
+	c := &RepoCache{}
+
+	const goroutines = 50
+	const incrementsPerGoroutine = 200
+
+	counter := 0
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < incrementsPerGoroutine; j++ {
+				err := c.WithLock(func() error {
+					counter++
+					return nil
+				})
+				if err != nil {
+					t.Errorf("unexpected error from WithLock: %v", err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	want := goroutines * incrementsPerGoroutine
+	if counter != want {
+		t.Fatalf("got %d increments, want %d -- WithLock let callers race", counter, want)
+	}
+}
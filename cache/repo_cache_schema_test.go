@@ -0,0 +1,25 @@
+package cache
+
+import "testing"
+
+// TestValidateExcerptsSchemaRejectsMismatch checks that an excerpt written
+// with an older/newer schema version is rejected. loadExcerpts returns that
+// error, and NewRepoCache answers it with a full buildAllExcerpt instead of
+// trusting a stale on-disk format.
+func TestValidateExcerptsSchemaRejectsMismatch(t *testing.T) {
+	upToDate := map[string]*BugExcerpt{
+		"abc": {SchemaVersion: excerptsSchemaVersion, Id: "abc"},
+	}
+
+	if err := validateExcerptsSchema(upToDate, "cache"); err != nil {
+		t.Fatalf("expected no error for an up to date schema, got %v", err)
+	}
+
+	stale := map[string]*BugExcerpt{
+		"abc": {SchemaVersion: excerptsSchemaVersion - 1, Id: "abc"},
+	}
+
+	if err := validateExcerptsSchema(stale, "cache"); err == nil {
+		t.Fatal("expected a schema mismatch to be reported as an error")
+	}
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/spf13/cobra"
+)
+
+func runBridgeConfigure(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: git bug bridge configure <github|gitlab> <owner/repo>")
+	}
+
+	if _, err := bridge.New(args[0]); err != nil {
+		return err
+	}
+
+	err := bridge.Configure(repo, bridge.Config{Name: args[0], Repo: args[1]})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("bridge %s configured for %s\n", args[0], args[1])
+	return nil
+}
+
+func runBridgePull(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: git bug bridge pull <github|gitlab> <token>")
+	}
+
+	b, err := bridge.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	return b.Import(repo, args[1])
+}
+
+func runBridgePush(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: git bug bridge push <github|gitlab> <token>")
+	}
+
+	b, err := bridge.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	return b.Export(repo, args[1])
+}
+
+var bridgeCmd = &cobra.Command{
+	Use:   "bridge",
+	Short: "Configure and use bridges to external issue trackers",
+}
+
+var bridgeConfigureCmd = &cobra.Command{
+	Use:   "configure <github|gitlab> <owner/repo>",
+	Short: "Configure a bridge to an external repo",
+	RunE:  runBridgeConfigure,
+}
+
+var bridgePullCmd = &cobra.Command{
+	Use:   "pull <github|gitlab> <token>",
+	Short: "Import issues from the configured bridge",
+	RunE:  runBridgePull,
+}
+
+var bridgePushCmd = &cobra.Command{
+	Use:   "push <github|gitlab> <token>",
+	Short: "Export bugs to the configured bridge",
+	RunE:  runBridgePush,
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeConfigureCmd)
+	bridgeCmd.AddCommand(bridgePullCmd)
+	bridgeCmd.AddCommand(bridgePushCmd)
+	RootCmd.AddCommand(bridgeCmd)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/spf13/cobra"
+)
+
+func runLabel(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a bug id")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	b, err := backend.ResolveBugPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		for _, l := range b.Snapshot().Labels {
+			fmt.Println(l)
+		}
+		return nil
+	}
+
+	var added, removed []bug.Label
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			removed = append(removed, bug.Label(strings.TrimPrefix(arg, "-")))
+		} else {
+			added = append(added, bug.Label(arg))
+		}
+	}
+
+	return b.ChangeLabels(added, removed)
+}
+
+// labelCmd defines the "label" subcommand.
+var labelCmd = &cobra.Command{
+	Use:   "label <id> [label|-label]...",
+	Short: "Display or change a bug's labels",
+	RunE:  runLabel,
+}
+
+func init() {
+	RootCmd.AddCommand(labelCmd)
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/spf13/cobra"
+)
+
+func runLs(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	var query *cache.Query
+	if len(args) > 0 {
+		query, err = cache.NewQuery(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, id := range backend.QueryBugs(query) {
+		b, err := backend.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		snap := b.Snapshot()
+		fmt.Printf("%s\t%s\n", id[:7], snap.Title)
+	}
+
+	return nil
+}
+
+// lsCmd defines the "ls" subcommand.
+var lsCmd = &cobra.Command{
+	Use:   "ls [<query>]",
+	Short: `List bugs, optionally filtered by a query string (e.g. "status:open label:bug")`,
+	RunE:  runLs,
+}
+
+func init() {
+	RootCmd.AddCommand(lsCmd)
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/spf13/cobra"
+)
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide a bug id")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	b, err := backend.ResolveBugPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		fmt.Println(b.Snapshot().Status)
+		return nil
+	}
+
+	switch args[1] {
+	case "open":
+		return b.Open()
+	case "close":
+		return b.Close()
+	default:
+		return fmt.Errorf("unknown status: %s", args[1])
+	}
+}
+
+// statusCmd defines the "status" subcommand.
+var statusCmd = &cobra.Command{
+	Use:   "status <id> [open|close]",
+	Short: "Display or change a bug status",
+	RunE:  runStatus,
+}
+
+func init() {
+	RootCmd.AddCommand(statusCmd)
+}
@@ -18,6 +18,7 @@ type AddCommentOperation struct {
 
 func (op AddCommentOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
 	comment := bug.Comment{
+		Hash:     op.Hash(),
 		Message:  op.Message,
 		Author:   op.Author,
 		Files:    op.files,
@@ -33,6 +34,12 @@ func (op AddCommentOperation) Files() []git.Hash {
 	return op.files
 }
 
+// Hash identify the operation, including the message this AddCommentOperation
+// adds on top of OpBase, not just the author and timestamp.
+func (op AddCommentOperation) Hash() git.Hash {
+	return bug.Hash(op)
+}
+
 func NewAddCommentOp(author bug.Person, message string, files []git.Hash) AddCommentOperation {
 	return AddCommentOperation{
 		OpBase:  bug.NewOpBase(bug.AddCommentOp, author),
@@ -20,6 +20,10 @@ func (op CreateOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
 	snapshot.Title = op.Title
 	snapshot.Comments = []bug.Comment{
 		{
+			// The root message is addressed by the hash of the operation
+			// that created it, same as any later AddCommentOperation, so
+			// EditCommentOperation can target it indifferently.
+			Hash:     op.Hash(),
 			Message:  op.Message,
 			Author:   op.Author,
 			UnixTime: op.UnixTime,
@@ -27,6 +31,7 @@ func (op CreateOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
 	}
 	snapshot.Author = op.Author
 	snapshot.CreatedAt = op.Time()
+	snapshot.Status = bug.OpenStatus
 	return snapshot
 }
 
@@ -34,6 +39,12 @@ func (op CreateOperation) GetFiles() []git.Hash {
 	return op.Files
 }
 
+// Hash identify the operation, including the fields CreateOperation adds on
+// top of OpBase (title/message/files), not just the author and timestamp.
+func (op CreateOperation) Hash() git.Hash {
+	return bug.Hash(op)
+}
+
 func NewCreateOp(author bug.Person, title, message string, files []git.Hash) CreateOperation {
 	return CreateOperation{
 		OpBase:  bug.NewOpBase(bug.CreateOp, author),
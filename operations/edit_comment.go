@@ -0,0 +1,98 @@
+package operations
+
+import (
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// EditCommentOperation will change a comment in the bug
+
+var _ bug.Operation = EditCommentOperation{}
+
+type EditCommentOperation struct {
+	bug.OpBase
+	// Target is the hash of the operation that created the targeted comment
+	// (CreateOperation for the root message, AddCommentOperation otherwise).
+	// Addressing by hash keeps the edit tamper-evident and stable across pulls.
+	Target  git.Hash
+	Message string
+}
+
+// Hash identify the operation, including the target/message this
+// EditCommentOperation adds on top of OpBase, not just the author and
+// timestamp -- two edits by the same author in the same second must not
+// collide just because OpBase's fields happen to match.
+func (op EditCommentOperation) Hash() git.Hash {
+	return bug.Hash(op)
+}
+
+func (op EditCommentOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
+	hash := op.Hash()
+
+	for i, comment := range snapshot.Comments {
+		if comment.Hash != op.Target {
+			continue
+		}
+
+		// Merges can replay edits out of order: keep whichever version has
+		// the latest timestamp, breaking ties on the operation hash so every
+		// peer converges on the same result. Either way, the version that
+		// loses the tie-break still gets recorded in History -- otherwise
+		// the two merge orders would disagree on what History contains, not
+		// just on what the current Message is.
+		if comment.Edited && !supersedes(op.UnixTime, hash, comment.UnixTime, comment.EditHash) {
+			comment.History = append(comment.History, bug.CommentEdit{
+				Message:  op.Message,
+				UnixTime: op.UnixTime,
+			})
+			// An older edit replayed late keeps History oldest first.
+			history := comment.History
+			sort.SliceStable(history, func(a, b int) bool { return history[a].UnixTime < history[b].UnixTime })
+			snapshot.Comments[i] = comment
+			return snapshot
+		}
+
+		comment.History = append(comment.History, bug.CommentEdit{
+			Message:  comment.Message,
+			UnixTime: comment.UnixTime,
+		})
+		history := comment.History
+		sort.SliceStable(history, func(a, b int) bool { return history[a].UnixTime < history[b].UnixTime })
+
+		comment.Message = op.Message
+		comment.UnixTime = op.UnixTime
+		comment.Edited = true
+		comment.EditHash = hash
+
+		snapshot.Comments[i] = comment
+		return snapshot
+	}
+
+	return snapshot
+}
+
+// supersedes reports whether the operation (t1, h1) should win over the one
+// currently applied to a comment or title (t2, h2).
+func supersedes(t1 int64, h1 git.Hash, t2 int64, h2 git.Hash) bool {
+	if t1 != t2 {
+		return t1 > t2
+	}
+	return h1 > h2
+}
+
+func NewEditCommentOp(author bug.Person, target git.Hash, message string) EditCommentOperation {
+	return EditCommentOperation{
+		OpBase:  bug.NewOpBase(bug.EditCommentOp, author),
+		Target:  target,
+		Message: message,
+	}
+}
+
+// EditComment is a convenience function to edit a comment, identified by the
+// hash of the operation that created it
+func EditComment(b bug.Interface, author bug.Person, target git.Hash, message string) {
+	editCommentOp := NewEditCommentOp(author, target, message)
+	b.Append(editCommentOp)
+}
@@ -0,0 +1,90 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// applyAll replay ops in order and return the resulting snapshot, simulating
+// what Bug.Compile does for a given merge order.
+func applyAll(ops []bug.Operation) bug.Snapshot {
+	snap := bug.Snapshot{}
+	for _, op := range ops {
+		snap = op.Apply(snap)
+	}
+	return snap
+}
+
+// TestEditCommentMergeOrderIndependent checks that two conflicting edits of
+// the same comment converge to the same result regardless of the order
+// they're replayed in, the scenario a merge of two peers' history can hit.
+func TestEditCommentMergeOrderIndependent(t *testing.T) {
+	author := bug.Person{Name: "rene"}
+
+	create := NewCreateOp(author, "title", "original message", nil)
+	target := create.Hash()
+
+	earlier := NewEditCommentOp(author, target, "edit from peer A")
+	earlier.UnixTime = 100
+
+	later := NewEditCommentOp(author, target, "edit from peer B")
+	later.UnixTime = 200
+
+	forward := applyAll([]bug.Operation{create, earlier, later})
+	backward := applyAll([]bug.Operation{create, later, earlier})
+
+	if forward.Comments[0].Message != backward.Comments[0].Message {
+		t.Fatalf("merge order changed the winning message: %q vs %q",
+			forward.Comments[0].Message, backward.Comments[0].Message)
+	}
+
+	if forward.Comments[0].Message != "edit from peer B" {
+		t.Fatalf("expected the latest timestamp to win, got %q", forward.Comments[0].Message)
+	}
+
+	if !forward.Comments[0].Edited || len(forward.Comments[0].History) == 0 {
+		t.Fatalf("expected the superseded edit(s) to be kept in history, got %+v", forward.Comments[0])
+	}
+
+	if len(forward.Comments[0].History) != len(backward.Comments[0].History) {
+		t.Fatalf("merge order changed the number of history entries: %+v vs %+v",
+			forward.Comments[0].History, backward.Comments[0].History)
+	}
+
+	for i, entry := range forward.Comments[0].History {
+		if entry != backward.Comments[0].History[i] {
+			t.Fatalf("merge order changed history entry %d: %+v vs %+v",
+				i, forward.Comments[0].History, backward.Comments[0].History)
+		}
+	}
+}
+
+// TestEditCommentTieBrokenByHash checks that two edits sharing the same
+// timestamp still converge deterministically, broken by operation hash
+// rather than merge order.
+func TestEditCommentTieBrokenByHash(t *testing.T) {
+	author := bug.Person{Name: "rene"}
+
+	create := NewCreateOp(author, "title", "original message", nil)
+	target := create.Hash()
+
+	editA := NewEditCommentOp(author, target, "edit from peer A")
+	editA.UnixTime = 100
+
+	editB := NewEditCommentOp(author, target, "edit from peer B")
+	editB.UnixTime = 100
+
+	forward := applyAll([]bug.Operation{create, editA, editB})
+	backward := applyAll([]bug.Operation{create, editB, editA})
+
+	if forward.Comments[0].Message != backward.Comments[0].Message {
+		t.Fatalf("merge order changed the winning message on a timestamp tie: %q vs %q",
+			forward.Comments[0].Message, backward.Comments[0].Message)
+	}
+
+	if len(forward.Comments[0].History) != len(backward.Comments[0].History) {
+		t.Fatalf("merge order changed the number of history entries on a tie: %+v vs %+v",
+			forward.Comments[0].History, backward.Comments[0].History)
+	}
+}
@@ -0,0 +1,81 @@
+package operations
+
+import (
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// EditTitleOperation will change the title of a bug
+
+var _ bug.Operation = EditTitleOperation{}
+
+type EditTitleOperation struct {
+	bug.OpBase
+	Title string
+}
+
+// Hash identify the operation, including the title this EditTitleOperation
+// adds on top of OpBase, not just the author and timestamp -- two edits by
+// the same author in the same second must not collide just because OpBase's
+// fields happen to match.
+func (op EditTitleOperation) Hash() git.Hash {
+	return bug.Hash(op)
+}
+
+func (op EditTitleOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
+	hash := op.Hash()
+
+	// Same determinism rule as EditCommentOperation: latest timestamp wins,
+	// ties broken by operation hash, so replaying merges out of order still
+	// converges on the same title everywhere. Either way, the version that
+	// loses the tie-break still gets recorded in TitleHistory -- otherwise
+	// the two merge orders would disagree on what TitleHistory contains, not
+	// just on what the current Title is.
+	if snapshot.TitleEdited && !supersedes(op.UnixTime, hash, snapshot.TitleEditTime, snapshot.TitleEditHash) {
+		snapshot.TitleHistory = append(snapshot.TitleHistory, bug.TitleEdit{
+			Title:    op.Title,
+			UnixTime: op.UnixTime,
+		})
+		// An older edit replayed late keeps TitleHistory oldest first.
+		history := snapshot.TitleHistory
+		sort.SliceStable(history, func(a, b int) bool { return history[a].UnixTime < history[b].UnixTime })
+		return snapshot
+	}
+
+	// The very first edit replaces the title set at creation time, which
+	// never went through this function: fall back to the bug's creation
+	// time instead of the zero-valued TitleEditTime.
+	previousTime := snapshot.TitleEditTime
+	if !snapshot.TitleEdited {
+		previousTime = snapshot.CreatedAt.Unix()
+	}
+
+	snapshot.TitleHistory = append(snapshot.TitleHistory, bug.TitleEdit{
+		Title:    snapshot.Title,
+		UnixTime: previousTime,
+	})
+	history := snapshot.TitleHistory
+	sort.SliceStable(history, func(a, b int) bool { return history[a].UnixTime < history[b].UnixTime })
+
+	snapshot.Title = op.Title
+	snapshot.TitleEdited = true
+	snapshot.TitleEditTime = op.UnixTime
+	snapshot.TitleEditHash = hash
+
+	return snapshot
+}
+
+func NewEditTitleOp(author bug.Person, title string) EditTitleOperation {
+	return EditTitleOperation{
+		OpBase: bug.NewOpBase(bug.EditTitleOp, author),
+		Title:  title,
+	}
+}
+
+// EditTitle is a convenience function to change a bug's title
+func EditTitle(b bug.Interface, author bug.Person, title string) {
+	editTitleOp := NewEditTitleOp(author, title)
+	b.Append(editTitleOp)
+}
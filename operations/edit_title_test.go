@@ -0,0 +1,101 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// TestEditTitleMergeOrderIndependent checks that two conflicting title edits
+// converge to the same title and history regardless of replay order, the
+// scenario a merge of two peers' history can hit.
+func TestEditTitleMergeOrderIndependent(t *testing.T) {
+	author := bug.Person{Name: "rene"}
+
+	create := NewCreateOp(author, "original title", "message", nil)
+
+	earlier := NewEditTitleOp(author, "title from peer A")
+	earlier.UnixTime = 100
+
+	later := NewEditTitleOp(author, "title from peer B")
+	later.UnixTime = 200
+
+	forward := applyAll([]bug.Operation{create, earlier, later})
+	backward := applyAll([]bug.Operation{create, later, earlier})
+
+	if forward.Title != backward.Title {
+		t.Fatalf("merge order changed the winning title: %q vs %q", forward.Title, backward.Title)
+	}
+
+	if forward.Title != "title from peer B" {
+		t.Fatalf("expected the latest timestamp to win, got %q", forward.Title)
+	}
+
+	if !forward.TitleEdited || len(forward.TitleHistory) == 0 {
+		t.Fatalf("expected the superseded edit(s) to be kept in TitleHistory, got %+v", forward)
+	}
+
+	if len(forward.TitleHistory) != len(backward.TitleHistory) {
+		t.Fatalf("merge order changed the number of title history entries: %+v vs %+v",
+			forward.TitleHistory, backward.TitleHistory)
+	}
+
+	for i, entry := range forward.TitleHistory {
+		if entry != backward.TitleHistory[i] {
+			t.Fatalf("merge order changed title history entry %d: %+v vs %+v",
+				i, forward.TitleHistory, backward.TitleHistory)
+		}
+	}
+}
+
+// TestEditTitleFirstEditUsesCreationTime checks that the original title's
+// history entry records the bug's creation time, not the zero value that
+// TitleEditTime holds before any edit has happened.
+func TestEditTitleFirstEditUsesCreationTime(t *testing.T) {
+	author := bug.Person{Name: "rene"}
+
+	create := NewCreateOp(author, "original title", "message", nil)
+	create.UnixTime = 42
+
+	edit := NewEditTitleOp(author, "new title")
+	edit.UnixTime = 100
+
+	snap := applyAll([]bug.Operation{create, edit})
+
+	if len(snap.TitleHistory) != 1 {
+		t.Fatalf("expected exactly one title history entry, got %+v", snap.TitleHistory)
+	}
+
+	if snap.TitleHistory[0].UnixTime != create.UnixTime {
+		t.Fatalf("expected the original title's history entry to use the creation time %d, got %d",
+			create.UnixTime, snap.TitleHistory[0].UnixTime)
+	}
+}
+
+// TestEditTitleTieBrokenByHash checks that two edits sharing the same
+// timestamp still converge deterministically, broken by operation hash
+// rather than merge order.
+func TestEditTitleTieBrokenByHash(t *testing.T) {
+	author := bug.Person{Name: "rene"}
+
+	create := NewCreateOp(author, "original title", "message", nil)
+
+	editA := NewEditTitleOp(author, "title from peer A")
+	editA.UnixTime = 100
+
+	editB := NewEditTitleOp(author, "title from peer B")
+	editB.UnixTime = 100
+
+	forward := applyAll([]bug.Operation{create, editA, editB})
+	backward := applyAll([]bug.Operation{create, editB, editA})
+
+	if forward.Title != backward.Title {
+		t.Fatalf("merge order changed the winning title on a timestamp tie: %q vs %q",
+			forward.Title, backward.Title)
+	}
+
+	if len(forward.TitleHistory) != len(backward.TitleHistory) {
+		t.Fatalf("merge order changed the number of title history entries on a tie: %+v vs %+v",
+			forward.TitleHistory, backward.TitleHistory)
+	}
+}
@@ -0,0 +1,57 @@
+package operations
+
+import (
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// LabelChangeOperation define a set of labels added and removed from a bug,
+// applied atomically
+
+var _ bug.Operation = LabelChangeOperation{}
+
+type LabelChangeOperation struct {
+	bug.OpBase
+	Added   []bug.Label
+	Removed []bug.Label
+}
+
+func (op LabelChangeOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
+	labels := make(map[bug.Label]bool, len(snapshot.Labels))
+	for _, l := range snapshot.Labels {
+		labels[l] = true
+	}
+
+	// Added and Removed are applied atomically: a label present in both ends
+	// up removed, so there is no ordering ambiguity within a single operation.
+	for _, l := range op.Added {
+		labels[l] = true
+	}
+	for _, l := range op.Removed {
+		delete(labels, l)
+	}
+
+	result := make([]bug.Label, 0, len(labels))
+	for l := range labels {
+		result = append(result, l)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	snapshot.Labels = result
+
+	return snapshot
+}
+
+func NewLabelChangeOp(author bug.Person, added, removed []bug.Label) LabelChangeOperation {
+	return LabelChangeOperation{
+		OpBase:  bug.NewOpBase(bug.LabelChangeOp, author),
+		Added:   added,
+		Removed: removed,
+	}
+}
+
+// ChangeLabels is a convenience function to add and/or remove labels from a bug
+func ChangeLabels(b bug.Interface, author bug.Person, added, removed []bug.Label) {
+	b.Append(NewLabelChangeOp(author, added, removed))
+}
@@ -0,0 +1,16 @@
+package operations
+
+import (
+	"encoding/gob"
+)
+
+// Operations are persisted as a gob-encoded []bug.Operation: gob needs every
+// concrete type it might find behind the interface registered up front.
+func init() {
+	gob.Register(CreateOperation{})
+	gob.Register(AddCommentOperation{})
+	gob.Register(EditCommentOperation{})
+	gob.Register(EditTitleOperation{})
+	gob.Register(SetStatusOperation{})
+	gob.Register(LabelChangeOperation{})
+}
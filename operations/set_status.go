@@ -0,0 +1,34 @@
+package operations
+
+import "github.com/MichaelMure/git-bug/bug"
+
+// SetStatusOperation will change the status of a bug
+
+var _ bug.Operation = SetStatusOperation{}
+
+type SetStatusOperation struct {
+	bug.OpBase
+	Status bug.Status
+}
+
+func (op SetStatusOperation) Apply(snapshot bug.Snapshot) bug.Snapshot {
+	snapshot.Status = op.Status
+	return snapshot
+}
+
+func NewSetStatusOp(author bug.Person, status bug.Status) SetStatusOperation {
+	return SetStatusOperation{
+		OpBase: bug.NewOpBase(bug.SetStatusOp, author),
+		Status: status,
+	}
+}
+
+// Open is a convenience function to reopen a bug
+func Open(b bug.Interface, author bug.Person) {
+	b.Append(NewSetStatusOp(author, bug.OpenStatus))
+}
+
+// Close is a convenience function to close a bug
+func Close(b bug.Interface, author bug.Person) {
+	b.Append(NewSetStatusOp(author, bug.ClosedStatus))
+}